@@ -0,0 +1,467 @@
+// Command miniboid is the tactical target acquisition game server. It
+// wires HTTP and WebSocket handlers on top of the reusable miniboi
+// library, mirroring the bserv/botserv split: the library owns the
+// game logic, this binary only owns transport.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/c-grimshaw/mini-boi/miniboi"
+	"github.com/c-grimshaw/mini-boi/miniboi/wire"
+)
+
+var registry = miniboi.NewRegistry()
+
+var (
+	hubsMu sync.Mutex
+	hubs   = make(map[string]*miniboi.MissionHub)
+)
+
+// hubFor returns the MissionHub for a game, creating one on first use.
+func hubFor(g *miniboi.Game) *miniboi.MissionHub {
+	hubsMu.Lock()
+	defer hubsMu.Unlock()
+	if h, ok := hubs[g.ID]; ok {
+		return h
+	}
+	h := miniboi.NewMissionHub(g, g.Config.TickRate/5) // 5x game tick rate, ~20Hz at the default config
+	hubs[g.ID] = h
+	return h
+}
+
+func dropHub(id string) {
+	hubsMu.Lock()
+	defer hubsMu.Unlock()
+	if h, ok := hubs[id]; ok {
+		h.Stop()
+		delete(hubs, id)
+	}
+}
+
+// startRequest is the body of POST /game/start.
+type startRequest struct {
+	TickRateMS int     `json:"tick_rate_ms"`
+	BoardSize  float64 `json:"board_size"`
+}
+
+// POST /game/start - create a new game
+func gameStartHandler(w http.ResponseWriter, r *http.Request) {
+	var req startRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	}
+
+	cfg := miniboi.Config{BoardSize: req.BoardSize}
+	if req.TickRateMS > 0 {
+		cfg.TickRate = time.Duration(req.TickRateMS) * time.Millisecond
+	}
+
+	g := registry.Start(cfg)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"game_id": g.ID,
+		"config":  g.Config,
+	})
+	log.Printf("Game started: %s (tick=%s, board=%.0f)", g.ID, g.Config.TickRate, g.Config.BoardSize)
+}
+
+// GET /game/list - list live games
+func gameListHandler(w http.ResponseWriter, r *http.Request) {
+	games := registry.List()
+	ids := make([]string, 0, len(games))
+	for _, g := range games {
+		ids = append(ids, g.ID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"games": ids,
+	})
+}
+
+// GET /game/stats/{id} - stats for one game
+func gameStatsHandler(w http.ResponseWriter, r *http.Request) {
+	g, ok := registry.Get(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g.Stats())
+}
+
+// POST /game/stop/{id} - stop and remove a game
+func gameStopHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !registry.Stop(id) {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+	dropHub(id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"game_id": id,
+		"status":  "STOPPED",
+	})
+	log.Printf("Game stopped: %s", id)
+}
+
+// gameFromPath looks up the {id} game in the request path, writing a 404
+// and returning ok=false if it doesn't exist.
+func gameFromPath(w http.ResponseWriter, r *http.Request) (*miniboi.Game, bool) {
+	g, ok := registry.Get(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return nil, false
+	}
+	return g, true
+}
+
+// GET /game/{id}/mission/coordinates - serve challenge
+func coordinatesChallengeHandler(w http.ResponseWriter, r *http.Request) {
+	g, ok := gameFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	format, _ := wire.Negotiate(r)
+	challenge, expectedAnswer := g.NewChallenge(string(format))
+
+	w.Header().Set("Content-Type", wire.ContentType(format))
+	wire.NewEncoder(format, w).Encode(challenge)
+
+	log.Printf("[%s] Challenge issued: %s (closest: %s, encoding: %s)", g.ID, challenge.ChallengeID, expectedAnswer, format)
+}
+
+// defaultBulkTargets is how many targets GET .../coordinates/bulk
+// generates when the caller doesn't specify ?count, comfortably past
+// kdTreeCrossover so the k-d tree path is what actually gets exercised.
+const defaultBulkTargets = 20000
+
+// maxBulkTargets caps ?count so a careless load test can't ask the
+// server to allocate an unbounded number of targets.
+const maxBulkTargets = 100000
+
+// GET /game/{id}/mission/coordinates/bulk - serve a large challenge, for
+// exercising the k-d tree nearest-neighbor path (see kdTreeCrossover).
+func coordinatesBulkHandler(w http.ResponseWriter, r *http.Request) {
+	g, ok := gameFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	count := defaultBulkTargets
+	if q := r.URL.Query().Get("count"); q != "" {
+		if n, err := strconv.Atoi(q); err == nil && n > 0 {
+			count = n
+		}
+	}
+	if count > maxBulkTargets {
+		count = maxBulkTargets
+	}
+
+	format, _ := wire.Negotiate(r)
+	challenge, expectedAnswer := g.NewBulkChallenge(count, string(format))
+
+	w.Header().Set("Content-Type", wire.ContentType(format))
+	wire.NewEncoder(format, w).Encode(challenge)
+
+	log.Printf("[%s] Bulk challenge issued: %s (%d targets, closest: %s)", g.ID, challenge.ChallengeID, count, expectedAnswer)
+}
+
+// POST /game/{id}/mission/coordinates - submit answer
+func coordinatesAnswerHandler(w http.ResponseWriter, r *http.Request) {
+	g, ok := gameFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	decodeFormat, _ := wire.NegotiateDecode(r)
+	responseFormat, explicit := wire.Negotiate(r)
+
+	var response miniboi.TargetResponse
+	if err := wire.NewDecoder(decodeFormat, r.Body).Decode(&response); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	data, exists := g.Challenge(response.ChallengeID)
+	if !exists {
+		http.Error(w, "Challenge not found or expired", http.StatusNotFound)
+		return
+	}
+
+	// If the client didn't explicitly ask for a response format, answer
+	// back in whatever format the challenge itself was issued in.
+	if !explicit && data.Encoding != "" {
+		responseFormat = wire.Format(data.Encoding)
+	}
+	enc := wire.NewEncoder(responseFormat, w)
+	w.Header().Set("Content-Type", wire.ContentType(responseFormat))
+
+	elapsed := time.Since(data.CreatedAt)
+	if elapsed > time.Second {
+		g.ResolveChallenge(response.ChallengeID, response.PlayerID, "TIMEOUT")
+		http.Error(w, "TIME EXPIRED! Target lost, soldier!", http.StatusRequestTimeout)
+		log.Printf("[%s] TIMEOUT: %s took %.2f seconds", g.ID, response.ChallengeID, elapsed.Seconds())
+		return
+	}
+
+	result, _ := g.ScoreResponse(response)
+
+	switch result.Status {
+	case "HIT":
+		enc.Encode(map[string]interface{}{
+			"status":               "TARGET ACQUIRED",
+			"message":              "Closest target identified! Excellent work.",
+			"response_time":        fmt.Sprintf("%.3f seconds", elapsed.Seconds()),
+			"intercept_error_secs": fmt.Sprintf("%.3f", result.InterceptError),
+			"challenge_id":         response.ChallengeID,
+			"target_id":            response.ClosestID,
+		})
+		log.Printf("[%s] SUCCESS: %s identified %s in %.3f seconds", g.ID, response.ChallengeID, response.ClosestID, elapsed.Seconds())
+
+	case "BAD_WINDOW":
+		w.WriteHeader(http.StatusBadRequest)
+		enc.Encode(map[string]interface{}{
+			"status":       "INTERCEPT OUTSIDE TICK WINDOW",
+			"message":      "INTERCEPT OUTSIDE TICK WINDOW! Re-acquire and try again.",
+			"challenge_id": response.ChallengeID,
+		})
+		log.Printf("[%s] BAD WINDOW: %s predicted intercept at %.3fs", g.ID, response.ChallengeID, response.InterceptTime)
+
+	case "OCCLUDED":
+		w.WriteHeader(http.StatusBadRequest)
+		enc.Encode(map[string]interface{}{
+			"status":               "TARGET OCCLUDED",
+			"message":              "That target was closer, but an obstacle blocked your line of sight.",
+			"correct_target":       result.CorrectTarget,
+			"chosen_target":        result.ChosenTarget,
+			"intercept_error_secs": fmt.Sprintf("%.3f", result.InterceptError),
+			"challenge_id":         response.ChallengeID,
+		})
+		log.Printf("[%s] OCCLUDED: %s chose %s, which was closer than %s but not visible", g.ID, response.ChallengeID, response.ClosestID, result.CorrectTarget)
+
+	default: // "MISS"
+		w.WriteHeader(http.StatusBadRequest)
+		enc.Encode(map[string]interface{}{
+			"status":               "TARGET MISSED",
+			"message":              "Wrong target! Check your intercept math.",
+			"correct_target":       result.CorrectTarget,
+			"chosen_target":        result.ChosenTarget,
+			"intercept_error_secs": fmt.Sprintf("%.3f", result.InterceptError),
+			"challenge_id":         response.ChallengeID,
+		})
+		log.Printf("[%s] FAILED: %s chose %s instead of %s", g.ID, response.ChallengeID, response.ClosestID, result.CorrectTarget)
+	}
+}
+
+// GET /game/{id}/mission/obstacles/preview - debug view of obstacles
+func obstaclesPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	g, ok := gameFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	format, _ := wire.Negotiate(r)
+	w.Header().Set("Content-Type", wire.ContentType(format))
+	wire.NewEncoder(format, w).Encode(map[string]interface{}{
+		"obstacles": g.Obstacles(),
+	})
+}
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Single-purpose mission server, no browser origin to police.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+var wsClientIDs = miniboi.NewIdGenerator("CLIENT")
+
+// wsSink adapts a gorilla websocket connection to miniboi.MissionSink,
+// encoding every frame in whichever wire format this connection
+// negotiated at upgrade time.
+type wsSink struct {
+	conn    *websocket.Conn
+	send    chan []byte
+	format  wire.Format
+	msgType int
+}
+
+func newWsSink(conn *websocket.Conn, format wire.Format) *wsSink {
+	msgType := websocket.TextMessage
+	if format != wire.JSON {
+		msgType = websocket.BinaryMessage
+	}
+	return &wsSink{conn: conn, send: make(chan []byte, 16), format: format, msgType: msgType}
+}
+
+// Send implements miniboi.MissionSink.
+func (s *wsSink) Send(v interface{}) {
+	var buf bytes.Buffer
+	if err := wire.NewEncoder(s.format, &buf).Encode(v); err != nil {
+		log.Printf("failed to encode frame (%s): %v", s.format, err)
+		return
+	}
+	select {
+	case s.send <- buf.Bytes():
+	default:
+		log.Printf("dropping frame for slow client")
+	}
+}
+
+// Close implements miniboi.MissionSink. It closes the underlying
+// connection so the handler's blocked conn.ReadMessage call returns an
+// error and the connected client's goroutines unwind, even if the
+// client never disconnects on its own.
+func (s *wsSink) Close() {
+	s.conn.Close()
+}
+
+func (s *wsSink) writer() {
+	for msg := range s.send {
+		if err := s.conn.WriteMessage(s.msgType, msg); err != nil {
+			return
+		}
+	}
+}
+
+// GET /game/{id}/ws/mission - upgrade to a live boardstate stream
+func missionWebSocketHandler(w http.ResponseWriter, r *http.Request) {
+	g, ok := gameFromPath(w, r)
+	if !ok {
+		return
+	}
+	hub := hubFor(g)
+
+	format, _ := wire.Negotiate(r)
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("ws upgrade failed: %v", err)
+		return
+	}
+
+	clientID := wsClientIDs.Next()
+	sink := newWsSink(conn, format)
+	hub.Register(clientID, sink)
+	log.Printf("[%s] Mission client connected: %s (encoding: %s)", g.ID, clientID, format)
+
+	go sink.writer()
+	defer func() {
+		// Unregister before closing sink.send: broadcast() holds h.mu while
+		// it sends to every registered sink, so once Unregister returns
+		// under that same lock, no in-flight or future broadcast can still
+		// reach this sink and send on the channel we're about to close.
+		hub.Unregister(clientID)
+		close(sink.send)
+		conn.Close()
+		log.Printf("[%s] Mission client disconnected: %s", g.ID, clientID)
+	}()
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var instr miniboi.Instruction
+		if err := wire.NewDecoder(format, bytes.NewReader(raw)).Decode(&instr); err != nil {
+			sink.Send(miniboi.ErrorFrame{Type: miniboi.FrameError, Message: "invalid instruction payload"})
+			continue
+		}
+
+		if !hub.ChallengeExists(instr.ChallengeID) {
+			sink.Send(miniboi.ErrorFrame{
+				Type:        miniboi.FrameError,
+				ChallengeID: instr.ChallengeID,
+				Message:     "challenge not found or expired",
+			})
+			continue
+		}
+
+		sink.Send(hub.HandleInstruction(instr))
+	}
+}
+
+// GET /status
+func statusHandler(w http.ResponseWriter, r *http.Request) {
+	games := registry.List()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"server_status": "OPERATIONAL",
+		"active_games":  len(games),
+	})
+}
+
+func main() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /game/start", gameStartHandler)
+	mux.HandleFunc("GET /game/list", gameListHandler)
+	mux.HandleFunc("GET /game/stats/{id}", gameStatsHandler)
+	mux.HandleFunc("POST /game/stop/{id}", gameStopHandler)
+	mux.HandleFunc("GET /game/{id}/mission/coordinates", coordinatesChallengeHandler)
+	mux.HandleFunc("POST /game/{id}/mission/coordinates", coordinatesAnswerHandler)
+	mux.HandleFunc("GET /game/{id}/mission/coordinates/bulk", coordinatesBulkHandler)
+	mux.HandleFunc("GET /game/{id}/mission/obstacles/preview", obstaclesPreviewHandler)
+	mux.HandleFunc("GET /game/{id}/ws/mission", missionWebSocketHandler)
+	mux.HandleFunc("GET /status", statusHandler)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintf(w, `TACTICAL TARGET ACQUISITION CHALLENGE SERVER
+==========================================
+
+Mission: Identify the closest target to your position (0,0,0)
+
+Games are independent sessions with their own tick rate, board size,
+scoreboard and challenge history. Start one before playing.
+
+Endpoints:
+  POST /game/start                             - Start a new game, returns {game_id, config}
+  GET  /game/list                              - List live game IDs
+  GET  /game/stats/{id}                        - Scoreboard and history for a game
+  POST /game/stop/{id}                         - Stop and remove a game
+  GET  /game/{id}/mission/coordinates          - Get target identification challenge
+  POST /game/{id}/mission/coordinates          - Submit closest target ID (< 1 second!)
+  GET  /game/{id}/mission/coordinates/bulk     - Get a challenge with 10k-100k targets (?count=N)
+  GET  /game/{id}/mission/obstacles/preview    - Debug view of the game's obstacles
+  GET  /game/{id}/ws/mission                   - Live boardstate stream over WebSocket
+  GET  /status                                 - Server status
+
+Algorithm: Targets drift by (vx,vy,vz) every second. Pick the *visible*
+target that will be closest to the player at challenge_issued_at +
+intercept_time, using 3D distance on its drifted position. A target is
+visible only if the line from the player to it doesn't cross an
+obstacle's [minX,minY,maxX,maxY] box. intercept_time must fall within
+the current turn_duration tick window. Time limit: 1 second per
+challenge.
+`)
+	})
+
+	log.Printf("🎯 Tactical Target Acquisition Server starting on 0.0.0.0:6969")
+	log.Printf("📍 Start a game: POST http://0.0.0.0:6969/game/start")
+
+	if err := http.ListenAndServe("0.0.0.0:6969", mux); err != nil {
+		log.Fatal("Server failed to start:", err)
+	}
+}
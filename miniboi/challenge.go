@@ -0,0 +1,88 @@
+package miniboi
+
+import (
+	"math"
+	"time"
+)
+
+// TargetChallenge is a single round of the game: the player's position
+// and a set of candidate targets, the closest (visible) one of which
+// the client must identify.
+type TargetChallenge struct {
+	ChallengeID string       `json:"challenge_id"`
+	PlayerPos   Coordinate   `json:"player_position"`
+	Targets     []Coordinate `json:"targets"`
+	// Obstacles block line-of-sight between the player and a target; the
+	// "correct" target is the closest one the player can actually see.
+	Obstacles []Obstacle `json:"obstacles"`
+	// TurnDuration is the length, in seconds, of one world tick -
+	// intercept_time on the answer must land within the current tick.
+	TurnDuration float64 `json:"turn_duration"`
+	// IssuedAt is the precise moment (unix nanoseconds) the challenge was
+	// generated, the reference point intercept_time is measured from.
+	IssuedAt  int64 `json:"challenge_issued_at"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// TargetResponse is a client's answer to a TargetChallenge.
+type TargetResponse struct {
+	ChallengeID string `json:"challenge_id"`
+	ClosestID   string `json:"closest_target_id"`
+	// InterceptTime is how many seconds after IssuedAt the client predicts
+	// their chosen target is closest. Required for moving targets; a zero
+	// value scores against the positions at issue time.
+	InterceptTime float64 `json:"intercept_time"`
+	// PlayerID attributes the answer to a scoreboard entry; answers with
+	// no PlayerID are still scored but don't contribute to a scoreboard.
+	PlayerID string `json:"player_id,omitempty"`
+}
+
+// ChallengeData is what a Game keeps on file for a challenge it has
+// issued but not yet resolved.
+type ChallengeData struct {
+	Challenge      TargetChallenge
+	ExpectedAnswer string
+	CreatedAt      time.Time
+	// Encoding is the wire format (e.g. "json", "gob", "msgpack") the
+	// challenge was issued in, so a caller answering it can default to
+	// the same format without having to ask for it again.
+	Encoding string
+}
+
+// predictClosestTarget finds whichever visible target will be closest to
+// the player at t seconds after the targets were observed at their given
+// positions, integrating each target's drift and filtering out any whose
+// line-of-sight to the player is blocked by an obstacle at that time.
+func predictClosestTarget(playerPos Coordinate, targets []Coordinate, obstacles []Obstacle, t float64) string {
+	closestID := ""
+	minDistance := math.Inf(1)
+
+	for _, target := range targets {
+		pos := atTime(target, t)
+		if !hasLineOfSight(playerPos, pos, obstacles) {
+			continue
+		}
+		dist := distance3D(playerPos, pos)
+		if dist < minDistance {
+			minDistance = dist
+			closestID = target.ID
+		}
+	}
+
+	return closestID
+}
+
+// findClosestTarget is the degenerate t=0 case of predictClosestTarget,
+// kept for callers that only care about positions as observed. With no
+// obstacles to filter visibility against and enough targets to make it
+// worthwhile, it answers via a k-d tree instead of a linear scan - see
+// KDTree and kdTreeCrossover.
+func findClosestTarget(playerPos Coordinate, targets []Coordinate, obstacles []Obstacle) string {
+	if len(obstacles) == 0 && len(targets) >= kdTreeCrossover {
+		var tree KDTree
+		tree.Build(targets)
+		id, _ := tree.Nearest(playerPos)
+		return id
+	}
+	return predictClosestTarget(playerPos, targets, obstacles, 0)
+}
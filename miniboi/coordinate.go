@@ -0,0 +1,38 @@
+// Package miniboi holds the reusable pieces of the tactical target
+// acquisition game - coordinates, challenges, obstacles, the game
+// registry and spatial index - so that multiple binaries (the HTTP/WS
+// server in cmd/miniboid, future bots or load generators) can share one
+// implementation instead of forking it.
+package miniboi
+
+import "math"
+
+// Coordinate is a point in 3D space, optionally drifting over time.
+type Coordinate struct {
+	ID string  `json:"id"`
+	X  float64 `json:"x"`
+	Y  float64 `json:"y"`
+	Z  float64 `json:"z"`
+	// Velocity components, in units/second. Zero for the (stationary)
+	// player; targets drift by this much every TurnDuration seconds.
+	VX float64 `json:"vx,omitempty"`
+	VY float64 `json:"vy,omitempty"`
+	VZ float64 `json:"vz,omitempty"`
+}
+
+// distance3D calculates the 3D distance between two points.
+func distance3D(p1, p2 Coordinate) float64 {
+	dx := p1.X - p2.X
+	dy := p1.Y - p2.Y
+	dz := p1.Z - p2.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// atTime returns where a (possibly drifting) target is after t seconds
+// have elapsed, by integrating its velocity in a straight line.
+func atTime(c Coordinate, t float64) Coordinate {
+	c.X += c.VX * t
+	c.Y += c.VY * t
+	c.Z += c.VZ * t
+	return c
+}
@@ -0,0 +1,371 @@
+package miniboi
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Config controls the shape of a single Game: how fast its world ticks
+// and how big its play area is.
+type Config struct {
+	TickRate  time.Duration `json:"tick_rate"`
+	BoardSize float64       `json:"board_size"`
+}
+
+// DefaultConfig mirrors the tuning the original single-game server used.
+var DefaultConfig = Config{
+	TickRate:  250 * time.Millisecond,
+	BoardSize: 200,
+}
+
+// PlayerScore is one player's running tally within a Game.
+type PlayerScore struct {
+	Hits     int       `json:"hits"`
+	Misses   int       `json:"misses"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// ChallengeSummary is the permanent record kept in a Game's history once
+// a challenge has been resolved (answered, occluded, or timed out).
+type ChallengeSummary struct {
+	ChallengeID string    `json:"challenge_id"`
+	IssuedAt    time.Time `json:"issued_at"`
+	Status      string    `json:"status"`
+	PlayerID    string    `json:"player_id,omitempty"`
+}
+
+// Game is one independent play session: its own challenge store,
+// scoreboard, tick clock and history, so that many games can run side
+// by side instead of sharing the single global state the server used
+// to keep.
+type Game struct {
+	ID        string
+	Config    Config
+	CreatedAt time.Time
+
+	challengeIDs *IdGenerator
+
+	challengeMu sync.RWMutex
+	challenges  map[string]*ChallengeData
+
+	scoreMu sync.RWMutex
+	scores  map[string]*PlayerScore
+
+	historyMu sync.Mutex
+	history   []ChallengeSummary
+
+	tickMu    sync.RWMutex
+	tickStart time.Time
+
+	stopCh chan struct{}
+}
+
+// newGame builds a Game ready to serve challenges, and starts its world
+// tick and expired-challenge cleanup loops.
+func newGame(id string, cfg Config) *Game {
+	g := &Game{
+		ID:           id,
+		Config:       cfg,
+		CreatedAt:    time.Now(),
+		challengeIDs: NewIdGenerator("TARG"),
+		challenges:   make(map[string]*ChallengeData),
+		scores:       make(map[string]*PlayerScore),
+		tickStart:    time.Now(),
+		stopCh:       make(chan struct{}),
+	}
+	go g.runTickLoop()
+	return g
+}
+
+// runTickLoop advances the world clock and sweeps expired challenges on
+// the game's own tick rate, the same pattern the original single-game
+// server used for its boardstate turn counter.
+func (g *Game) runTickLoop() {
+	ticker := time.NewTicker(g.Config.TickRate)
+	cleanup := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-g.stopCh:
+			return
+		case <-ticker.C:
+			g.tickMu.Lock()
+			g.tickStart = time.Now()
+			g.tickMu.Unlock()
+		case <-cleanup.C:
+			g.cleanupExpiredChallenges()
+		}
+	}
+}
+
+func (g *Game) cleanupExpiredChallenges() {
+	g.challengeMu.Lock()
+	defer g.challengeMu.Unlock()
+
+	cutoff := time.Now().Add(-2 * time.Second)
+	for id, data := range g.challenges {
+		if data.CreatedAt.Before(cutoff) {
+			delete(g.challenges, id)
+		}
+	}
+}
+
+// withinCurrentTickWindow reports whether a client's predicted intercept
+// time, given in seconds since issuedAt, falls inside the tick window
+// that is active right now.
+func (g *Game) withinCurrentTickWindow(issuedAt time.Time, interceptTime float64) bool {
+	g.tickMu.RLock()
+	start := g.tickStart
+	g.tickMu.RUnlock()
+
+	predictedAt := issuedAt.Add(time.Duration(interceptTime * float64(time.Second)))
+	end := start.Add(g.Config.TickRate)
+	return !predictedAt.Before(start) && predictedAt.Before(end)
+}
+
+// NewChallenge generates a fresh TargetChallenge of 5-8 targets, records
+// it as pending (remembering encoding as the wire format it was issued
+// in), and returns it alongside the expected answer.
+func (g *Game) NewChallenge(encoding string) (TargetChallenge, string) {
+	return g.newChallenge(5+rand.Intn(4), true, encoding)
+}
+
+// NewBulkChallenge is NewChallenge's load-testing sibling: it generates
+// numTargets targets (10k-100k is the range that actually demonstrates
+// the k-d tree crossover - see kdTreeCrossover) and skips obstacles, so
+// findClosestTarget always takes the k-d tree path instead of falling
+// back to a linear scan for visibility filtering.
+func (g *Game) NewBulkChallenge(numTargets int, encoding string) (TargetChallenge, string) {
+	return g.newChallenge(numTargets, false, encoding)
+}
+
+func (g *Game) newChallenge(numTargets int, withObstacles bool, encoding string) (TargetChallenge, string) {
+	challengeID := g.challengeIDs.Next()
+
+	playerPos := Coordinate{ID: "PLAYER", X: 0, Y: 0, Z: 0}
+
+	targets := make([]Coordinate, numTargets)
+	for i := 0; i < numTargets; i++ {
+		targets[i] = Coordinate{
+			ID: fmt.Sprintf("T%d", i+1),
+			X:  (rand.Float64() - 0.5) * g.Config.BoardSize,
+			Y:  (rand.Float64() - 0.5) * g.Config.BoardSize,
+			Z:  (rand.Float64() - 0.5) * g.Config.BoardSize / 2,
+			VX: (rand.Float64() - 0.5) * 20,
+			VY: (rand.Float64() - 0.5) * 20,
+			VZ: (rand.Float64() - 0.5) * 10,
+		}
+	}
+
+	var obstacles []Obstacle
+	if withObstacles {
+		obstacles = generateObstacles(g.Config.BoardSize)
+	}
+
+	challenge := TargetChallenge{
+		ChallengeID:  challengeID,
+		PlayerPos:    playerPos,
+		Targets:      targets,
+		Obstacles:    obstacles,
+		TurnDuration: g.Config.TickRate.Seconds(),
+		IssuedAt:     time.Now().UnixNano(),
+		Timestamp:    time.Now().Unix(),
+	}
+	expectedAnswer := findClosestTarget(challenge.PlayerPos, challenge.Targets, challenge.Obstacles)
+
+	g.challengeMu.Lock()
+	g.challenges[challengeID] = &ChallengeData{
+		Challenge:      challenge,
+		ExpectedAnswer: expectedAnswer,
+		CreatedAt:      time.Now(),
+		Encoding:       encoding,
+	}
+	g.challengeMu.Unlock()
+
+	return challenge, expectedAnswer
+}
+
+// Challenge looks up a pending challenge by ID.
+func (g *Game) Challenge(id string) (*ChallengeData, bool) {
+	g.challengeMu.RLock()
+	defer g.challengeMu.RUnlock()
+	data, ok := g.challenges[id]
+	return data, ok
+}
+
+// Obstacles returns the obstacle set of whichever challenge is most
+// recently pending, for the debug preview endpoint.
+func (g *Game) Obstacles() []Obstacle {
+	g.challengeMu.RLock()
+	defer g.challengeMu.RUnlock()
+	var latest []Obstacle
+	var latestAt time.Time
+	for _, data := range g.challenges {
+		if data.CreatedAt.After(latestAt) {
+			latestAt = data.CreatedAt
+			latest = data.Challenge.Obstacles
+		}
+	}
+	return latest
+}
+
+// ResolveChallenge removes a challenge from the pending set, records the
+// outcome in the game's history and scoreboard, and returns the
+// challenge data that was resolved.
+func (g *Game) ResolveChallenge(id, playerID, status string) {
+	g.challengeMu.Lock()
+	data, exists := g.challenges[id]
+	delete(g.challenges, id)
+	g.challengeMu.Unlock()
+
+	if !exists {
+		return
+	}
+
+	g.historyMu.Lock()
+	g.history = append(g.history, ChallengeSummary{
+		ChallengeID: id,
+		IssuedAt:    data.CreatedAt,
+		Status:      status,
+		PlayerID:    playerID,
+	})
+	g.historyMu.Unlock()
+
+	if playerID == "" {
+		return
+	}
+	g.scoreMu.Lock()
+	defer g.scoreMu.Unlock()
+	score, ok := g.scores[playerID]
+	if !ok {
+		score = &PlayerScore{}
+		g.scores[playerID] = score
+	}
+	score.LastSeen = time.Now()
+	if status == "HIT" {
+		score.Hits++
+	} else {
+		score.Misses++
+	}
+}
+
+// Stats summarizes a Game for the /game/stats/{id} endpoint.
+type Stats struct {
+	GameID           string                  `json:"game_id"`
+	Config           Config                  `json:"config"`
+	CreatedAt        time.Time               `json:"created_at"`
+	ActiveChallenges int                     `json:"active_challenges"`
+	Scoreboard       map[string]*PlayerScore `json:"scoreboard"`
+	History          []ChallengeSummary      `json:"history"`
+}
+
+// Stats snapshots the game's current state.
+func (g *Game) Stats() Stats {
+	g.challengeMu.RLock()
+	active := len(g.challenges)
+	g.challengeMu.RUnlock()
+
+	g.scoreMu.RLock()
+	scores := make(map[string]*PlayerScore, len(g.scores))
+	for id, s := range g.scores {
+		copied := *s
+		scores[id] = &copied
+	}
+	g.scoreMu.RUnlock()
+
+	g.historyMu.Lock()
+	history := append([]ChallengeSummary(nil), g.history...)
+	g.historyMu.Unlock()
+
+	return Stats{
+		GameID:           g.ID,
+		Config:           g.Config,
+		CreatedAt:        g.CreatedAt,
+		ActiveChallenges: active,
+		Scoreboard:       scores,
+		History:          history,
+	}
+}
+
+// stop halts the game's tick and cleanup loops. Callers must hold (or
+// not need) the registry lock; Registry.Stop handles removal from the
+// registry itself.
+func (g *Game) stop() {
+	close(g.stopCh)
+}
+
+// Registry is a MapLock-style store of live Games, keyed by ID. It plays
+// the same role the old global activeChallenges map did, but one level
+// up: games own their own challenges instead of the server owning a
+// single flat pool.
+type Registry struct {
+	mu      sync.RWMutex
+	games   map[string]*Game
+	gameIDs *IdGenerator
+}
+
+// NewRegistry returns an empty game registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		games:   make(map[string]*Game),
+		gameIDs: NewIdGenerator("GAME"),
+	}
+}
+
+// Start creates and registers a new Game with the given config,
+// defaulting any zero fields to DefaultConfig.
+func (r *Registry) Start(cfg Config) *Game {
+	if cfg.TickRate <= 0 {
+		cfg.TickRate = DefaultConfig.TickRate
+	}
+	if cfg.BoardSize <= 0 {
+		cfg.BoardSize = DefaultConfig.BoardSize
+	}
+
+	g := newGame(r.gameIDs.Next(), cfg)
+
+	r.mu.Lock()
+	r.games[g.ID] = g
+	r.mu.Unlock()
+
+	return g
+}
+
+// Get looks up a Game by ID.
+func (r *Registry) Get(id string) (*Game, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.games[id]
+	return g, ok
+}
+
+// List returns every live Game, in no particular order.
+func (r *Registry) List() []*Game {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	games := make([]*Game, 0, len(r.games))
+	for _, g := range r.games {
+		games = append(games, g)
+	}
+	return games
+}
+
+// Stop halts and removes a Game from the registry. It reports whether
+// the game existed.
+func (r *Registry) Stop(id string) bool {
+	r.mu.Lock()
+	g, ok := r.games[id]
+	if ok {
+		delete(r.games, id)
+	}
+	r.mu.Unlock()
+
+	if ok {
+		g.stop()
+	}
+	return ok
+}
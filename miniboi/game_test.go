@@ -0,0 +1,62 @@
+package miniboi
+
+import (
+	"testing"
+	"time"
+)
+
+func testConfig() Config {
+	return Config{TickRate: 10 * time.Millisecond, BoardSize: 50}
+}
+
+func TestRegistryStartGetStop(t *testing.T) {
+	r := NewRegistry()
+	g := r.Start(testConfig())
+
+	got, ok := r.Get(g.ID)
+	if !ok || got != g {
+		t.Fatalf("Get(%q) = %v, %v; want %v, true", g.ID, got, ok, g)
+	}
+	if len(r.List()) != 1 {
+		t.Fatalf("List() = %d games, want 1", len(r.List()))
+	}
+
+	if !r.Stop(g.ID) {
+		t.Fatal("Stop() on a live game id returned false")
+	}
+	if _, ok := r.Get(g.ID); ok {
+		t.Fatal("game still present in registry after Stop")
+	}
+	if r.Stop(g.ID) {
+		t.Fatal("Stop() on an already-stopped game id returned true")
+	}
+}
+
+func TestGameChallengeLifecycle(t *testing.T) {
+	r := NewRegistry()
+	g := r.Start(testConfig())
+	defer r.Stop(g.ID)
+
+	challenge, expected := g.NewChallenge("json")
+	if expected == "" {
+		t.Fatal("NewChallenge returned an empty expected answer")
+	}
+
+	data, ok := g.Challenge(challenge.ChallengeID)
+	if !ok || data.ExpectedAnswer != expected {
+		t.Fatalf("Challenge(%q) = %+v, %v; want ExpectedAnswer=%q", challenge.ChallengeID, data, ok, expected)
+	}
+
+	g.ResolveChallenge(challenge.ChallengeID, "PLAYER1", "HIT")
+	if _, ok := g.Challenge(challenge.ChallengeID); ok {
+		t.Fatal("challenge still pending after ResolveChallenge")
+	}
+
+	stats := g.Stats()
+	if stats.Scoreboard["PLAYER1"] == nil || stats.Scoreboard["PLAYER1"].Hits != 1 {
+		t.Fatalf("Stats().Scoreboard[PLAYER1] = %+v, want Hits=1", stats.Scoreboard["PLAYER1"])
+	}
+	if len(stats.History) != 1 || stats.History[0].Status != "HIT" {
+		t.Fatalf("Stats().History = %+v, want one HIT entry", stats.History)
+	}
+}
@@ -0,0 +1,29 @@
+package miniboi
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+)
+
+// IdGenerator produces IDs of the form "<prefix>-<seq>-<rand>": a
+// monotonic counter plus a random suffix, so IDs stay unique across
+// restarts and concurrent generators without a central allocator.
+// Replaces the old rand.Intn(999999) scheme, which could collide once
+// enough games or challenges were issued.
+type IdGenerator struct {
+	prefix string
+	seq    uint64
+}
+
+// NewIdGenerator returns an IdGenerator that produces IDs starting with
+// prefix, e.g. "GAME" or "TARG".
+func NewIdGenerator(prefix string) *IdGenerator {
+	return &IdGenerator{prefix: prefix}
+}
+
+// Next returns the next ID from the generator.
+func (g *IdGenerator) Next() string {
+	seq := atomic.AddUint64(&g.seq, 1)
+	return fmt.Sprintf("%s-%d-%04d", g.prefix, seq, rand.Intn(10000))
+}
@@ -0,0 +1,116 @@
+package miniboi
+
+import (
+	"math"
+	"sort"
+)
+
+// kdTreeCrossover is the target-set size above which building a k-d
+// tree wins over a linear scan; below it the recursion and allocation
+// overhead costs more than the comparisons it saves. See
+// BenchmarkFindClosest in kdtree_bench_test.go for where that crossover
+// actually falls.
+const kdTreeCrossover = 32
+
+// KDTree is a 3D k-d tree over a fixed set of Coordinates, built once
+// per query set and used to answer repeated nearest-neighbor lookups
+// in O(log n) average time instead of the O(n) scan a naive nearest-
+// target search does.
+type KDTree struct {
+	root *kdNode
+}
+
+type kdNode struct {
+	point       Coordinate
+	axis        int
+	left, right *kdNode
+}
+
+// Build constructs the tree over points, splitting on the median of the
+// x, y, z axes in turn (cycling by depth) so each split halves the
+// remaining points regardless of their distribution.
+func (t *KDTree) Build(points []Coordinate) {
+	pts := make([]Coordinate, len(points))
+	copy(pts, points)
+	t.root = buildKDNode(pts, 0)
+}
+
+func buildKDNode(points []Coordinate, depth int) *kdNode {
+	if len(points) == 0 {
+		return nil
+	}
+
+	axis := depth % 3
+	sort.Slice(points, func(i, j int) bool {
+		return axisValue(points[i], axis) < axisValue(points[j], axis)
+	})
+
+	mid := len(points) / 2
+	return &kdNode{
+		point: points[mid],
+		axis:  axis,
+		left:  buildKDNode(points[:mid], depth+1),
+		right: buildKDNode(points[mid+1:], depth+1),
+	}
+}
+
+// Nearest returns the ID and distance of the point in the tree closest
+// to query. It reports ("", +Inf) for an empty tree.
+func (t *KDTree) Nearest(query Coordinate) (id string, dist float64) {
+	if t.root == nil {
+		return "", math.Inf(1)
+	}
+
+	bestID := ""
+	bestSq := math.Inf(1)
+
+	var search func(n *kdNode)
+	search = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+
+		if d := sqDist3D(n.point, query); d < bestSq {
+			bestSq = d
+			bestID = n.point.ID
+		}
+
+		// Which side of the splitting plane the query falls on decides
+		// which child to descend into first.
+		diff := axisValue(query, n.axis) - axisValue(n.point, n.axis)
+		near, far := n.left, n.right
+		if diff > 0 {
+			near, far = n.right, n.left
+		}
+
+		search(near)
+		// Only the far subtree can possibly hold a closer point, and
+		// only if the plane itself is nearer than our current best -
+		// comparing squared distances throughout keeps this a single
+		// multiply instead of a sqrt per node.
+		if diff*diff < bestSq {
+			search(far)
+		}
+	}
+	search(t.root)
+
+	return bestID, math.Sqrt(bestSq)
+}
+
+func axisValue(c Coordinate, axis int) float64 {
+	switch axis {
+	case 0:
+		return c.X
+	case 1:
+		return c.Y
+	default:
+		return c.Z
+	}
+}
+
+func sqDist3D(a, b Coordinate) float64 {
+	dx := a.X - b.X
+	dy := a.Y - b.Y
+	dz := a.Z - b.Z
+	return dx*dx + dy*dy + dz*dz
+}
@@ -0,0 +1,60 @@
+package miniboi
+
+import (
+	"strconv"
+	"testing"
+)
+
+// linearNearest is the scan findClosestTarget used before the k-d tree,
+// kept here only to benchmark against.
+func linearNearest(query Coordinate, coords []Coordinate) string {
+	bestID := ""
+	bestSq := -1.0
+	for _, c := range coords {
+		d := sqDist3D(c, query)
+		if bestSq < 0 || d < bestSq {
+			bestSq = d
+			bestID = c.ID
+		}
+	}
+	return bestID
+}
+
+// BenchmarkFindClosest compares the linear scan against the k-d tree at
+// a range of target-set sizes straddling kdTreeCrossover (32), so
+// `go test -bench FindClosest -benchtime` output shows where the
+// crossover actually pays off.
+func BenchmarkFindClosest(b *testing.B) {
+	query := Coordinate{X: 1, Y: 2, Z: 3}
+
+	for _, n := range []int{8, 16, 32, 64, 256, 1024, 10000, 100000} {
+		coords := randomCoordinates(n)
+
+		b.Run(benchName("Linear", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				linearNearest(query, coords)
+			}
+		})
+
+		b.Run(benchName("KDTree", n), func(b *testing.B) {
+			var tree KDTree
+			tree.Build(coords)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tree.Nearest(query)
+			}
+		})
+
+		b.Run(benchName("KDTreeWithBuild", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var tree KDTree
+				tree.Build(coords)
+				tree.Nearest(query)
+			}
+		})
+	}
+}
+
+func benchName(label string, n int) string {
+	return label + "/n=" + strconv.Itoa(n)
+}
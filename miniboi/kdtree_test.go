@@ -0,0 +1,53 @@
+package miniboi
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func randomCoordinates(n int) []Coordinate {
+	coords := make([]Coordinate, n)
+	for i := range coords {
+		coords[i] = Coordinate{
+			ID: fmt.Sprintf("T%d", i),
+			X:  rand.Float64()*200 - 100,
+			Y:  rand.Float64()*200 - 100,
+			Z:  rand.Float64()*100 - 50,
+		}
+	}
+	return coords
+}
+
+func bruteNearest(query Coordinate, coords []Coordinate) (string, float64) {
+	bestID := ""
+	bestSq := -1.0
+	for _, c := range coords {
+		d := sqDist3D(c, query)
+		if bestSq < 0 || d < bestSq {
+			bestSq = d
+			bestID = c.ID
+		}
+	}
+	return bestID, bestSq
+}
+
+func TestKDTreeMatchesBruteForce(t *testing.T) {
+	query := Coordinate{X: 3, Y: -7, Z: 1}
+
+	for _, n := range []int{1, 2, 5, 31, 32, 200} {
+		coords := randomCoordinates(n)
+
+		var tree KDTree
+		tree.Build(coords)
+		gotID, gotDist := tree.Nearest(query)
+
+		wantID, wantSqDist := bruteNearest(query, coords)
+		if gotID != wantID {
+			t.Errorf("n=%d: KDTree.Nearest id = %q, want %q", n, gotID, wantID)
+		}
+		if diff := gotDist*gotDist - wantSqDist; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("n=%d: KDTree.Nearest dist = %v, want sqrt(%v)", n, gotDist, wantSqDist)
+		}
+	}
+}
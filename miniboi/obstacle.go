@@ -0,0 +1,99 @@
+package miniboi
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Obstacle is an axis-aligned box that can block line-of-sight between
+// the player and a target. Bounds mirrors the external MiniObstacle
+// convention of a flat [4]float64 of [minX minY maxX maxY] corners -
+// obstacles span the full Z range, so they behave like walls rather
+// than crates.
+type Obstacle struct {
+	ID     string     `json:"id"`
+	Bounds [4]float64 `json:"bounds"`
+}
+
+// generateObstacles scatters 0-3 random obstacles across the given
+// board footprint (boardSize is the half-width of the square play area
+// targets are generated in).
+func generateObstacles(boardSize float64) []Obstacle {
+	n := rand.Intn(4)
+	obstacles := make([]Obstacle, n)
+	for i := 0; i < n; i++ {
+		cx := (rand.Float64() - 0.5) * boardSize * 0.9
+		cy := (rand.Float64() - 0.5) * boardSize * 0.9
+		halfWidth := 5 + rand.Float64()*15
+		halfDepth := 5 + rand.Float64()*15
+		obstacles[i] = Obstacle{
+			ID:     fmt.Sprintf("OBS%d", i+1),
+			Bounds: [4]float64{cx - halfWidth, cy - halfDepth, cx + halfWidth, cy + halfDepth},
+		}
+	}
+	return obstacles
+}
+
+// segmentIntersectsObstacle runs a 2D ray/AABB slab test on the XY
+// projection of the from-to segment, since obstacles span all Z.
+func segmentIntersectsObstacle(from, to Coordinate, obs Obstacle) bool {
+	dx := to.X - from.X
+	dy := to.Y - from.Y
+
+	tMin, tMax := 0.0, 1.0
+
+	if dx == 0 {
+		if from.X < obs.Bounds[0] || from.X > obs.Bounds[2] {
+			return false
+		}
+	} else {
+		t1 := (obs.Bounds[0] - from.X) / dx
+		t2 := (obs.Bounds[2] - from.X) / dx
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tMin {
+			tMin = t1
+		}
+		if t2 < tMax {
+			tMax = t2
+		}
+		if tMin > tMax {
+			return false
+		}
+	}
+
+	if dy == 0 {
+		if from.Y < obs.Bounds[1] || from.Y > obs.Bounds[3] {
+			return false
+		}
+	} else {
+		t1 := (obs.Bounds[1] - from.Y) / dy
+		t2 := (obs.Bounds[3] - from.Y) / dy
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tMin {
+			tMin = t1
+		}
+		if t2 < tMax {
+			tMax = t2
+		}
+		if tMin > tMax {
+			return false
+		}
+	}
+
+	return tMin <= tMax
+}
+
+// hasLineOfSight reports whether the segment from the player to the
+// target is unobstructed by any of the given obstacles.
+func hasLineOfSight(from, to Coordinate, obstacles []Obstacle) bool {
+	for _, obs := range obstacles {
+		if segmentIntersectsObstacle(from, to, obs) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package miniboi
+
+import (
+	"math"
+	"time"
+)
+
+// ScoreResult is the outcome of scoring a TargetResponse against a
+// Game's pending challenge.
+type ScoreResult struct {
+	// Status is one of "HIT", "MISS", "OCCLUDED" (the chosen target was
+	// closer than the correct one but blocked by an obstacle), or
+	// "BAD_WINDOW" (intercept_time fell outside the current tick).
+	Status         string
+	CorrectTarget  string
+	ChosenTarget   string
+	InterceptError float64
+}
+
+// ScoreResponse resolves a pending challenge against a client's answer.
+// Unlike comparing against the answer's position at issue time, it
+// recomputes the closest visible target at response.InterceptTime -
+// targets drift, so the correct answer depends on when the client claims
+// to have intercepted - and rejects any intercept_time that falls
+// outside the challenge's current tick window. It resolves the
+// challenge (removing it from the pending set) as a side effect; ok is
+// false if the challenge didn't exist.
+func (g *Game) ScoreResponse(response TargetResponse) (result ScoreResult, ok bool) {
+	data, exists := g.Challenge(response.ChallengeID)
+	if !exists {
+		return ScoreResult{}, false
+	}
+
+	result.ChosenTarget = response.ClosestID
+	result.InterceptError = math.Abs(response.InterceptTime - time.Since(data.CreatedAt).Seconds())
+
+	if !g.withinCurrentTickWindow(data.CreatedAt, response.InterceptTime) {
+		g.ResolveChallenge(response.ChallengeID, response.PlayerID, "BAD_WINDOW")
+		result.Status = "BAD_WINDOW"
+		return result, true
+	}
+
+	challenge := data.Challenge
+	correct := predictClosestTarget(challenge.PlayerPos, challenge.Targets, challenge.Obstacles, response.InterceptTime)
+	result.CorrectTarget = correct
+
+	if response.ClosestID == correct {
+		g.ResolveChallenge(response.ChallengeID, response.PlayerID, "HIT")
+		result.Status = "HIT"
+		return result, true
+	}
+
+	// Wrong answer: work out whether the client picked a target that was
+	// actually closer than the correct one but occluded, which gets its
+	// own status rather than a plain miss. A ClosestID that doesn't match
+	// any real target (garbage ID, or one from an expired challenge)
+	// never qualifies - chosenFound guards against the zero-valued
+	// chosenDistance otherwise looking "closer than" anything.
+	var correctDistance, chosenDistance float64
+	var chosenVisible, chosenFound bool
+	for _, target := range challenge.Targets {
+		pos := atTime(target, response.InterceptTime)
+		if target.ID == correct {
+			correctDistance = distance3D(challenge.PlayerPos, pos)
+		}
+		if target.ID == response.ClosestID {
+			chosenDistance = distance3D(challenge.PlayerPos, pos)
+			chosenVisible = hasLineOfSight(challenge.PlayerPos, pos, challenge.Obstacles)
+			chosenFound = true
+		}
+	}
+
+	if chosenFound && !chosenVisible && chosenDistance < correctDistance {
+		g.ResolveChallenge(response.ChallengeID, response.PlayerID, "OCCLUDED")
+		result.Status = "OCCLUDED"
+		return result, true
+	}
+
+	g.ResolveChallenge(response.ChallengeID, response.PlayerID, "MISS")
+	result.Status = "MISS"
+	return result, true
+}
@@ -0,0 +1,79 @@
+package miniboi
+
+import (
+	"testing"
+	"time"
+)
+
+// putTestChallenge seeds g with a deterministic pending challenge,
+// bypassing newChallenge's random target/obstacle generation so tests
+// can pick exact positions and obstacles.
+func putTestChallenge(g *Game, id string, challenge TargetChallenge) {
+	g.challengeMu.Lock()
+	g.challenges[id] = &ChallengeData{
+		Challenge: challenge,
+		CreatedAt: time.Now(),
+	}
+	g.challengeMu.Unlock()
+}
+
+func TestScoreResponse(t *testing.T) {
+	g := newGame("TEST", Config{TickRate: time.Second, BoardSize: 200})
+	defer g.stop()
+
+	playerPos := Coordinate{ID: "PLAYER"}
+	near := Coordinate{ID: "NEAR", X: 5}
+	far := Coordinate{ID: "FAR", Y: 50}
+	// Blocks the player-to-NEAR segment (runs along Y=0, X from 0 to 5)
+	// but not the player-to-FAR segment (runs along X=0).
+	wall := Obstacle{ID: "WALL", Bounds: [4]float64{2, -5, 3, 5}}
+
+	challenge := TargetChallenge{
+		PlayerPos: playerPos,
+		Targets:   []Coordinate{near, far},
+		Obstacles: []Obstacle{wall},
+	}
+
+	t.Run("hit", func(t *testing.T) {
+		id := "C-HIT"
+		putTestChallenge(g, id, challenge)
+		result, ok := g.ScoreResponse(TargetResponse{ChallengeID: id, ClosestID: "FAR"})
+		if !ok || result.Status != "HIT" {
+			t.Fatalf("got %+v, ok=%v, want HIT", result, ok)
+		}
+	})
+
+	t.Run("occluded", func(t *testing.T) {
+		id := "C-OCCLUDED"
+		putTestChallenge(g, id, challenge)
+		result, ok := g.ScoreResponse(TargetResponse{ChallengeID: id, ClosestID: "NEAR"})
+		if !ok || result.Status != "OCCLUDED" {
+			t.Fatalf("got %+v, ok=%v, want OCCLUDED", result, ok)
+		}
+	})
+
+	t.Run("bogus closest id is a miss, not occluded", func(t *testing.T) {
+		id := "C-BOGUS"
+		putTestChallenge(g, id, challenge)
+		result, ok := g.ScoreResponse(TargetResponse{ChallengeID: id, ClosestID: "NOT-A-REAL-TARGET"})
+		if !ok || result.Status != "MISS" {
+			t.Fatalf("got %+v, ok=%v, want MISS", result, ok)
+		}
+	})
+
+	t.Run("intercept outside tick window", func(t *testing.T) {
+		id := "C-BADWINDOW"
+		putTestChallenge(g, id, challenge)
+		result, ok := g.ScoreResponse(TargetResponse{ChallengeID: id, ClosestID: "FAR", InterceptTime: 1000})
+		if !ok || result.Status != "BAD_WINDOW" {
+			t.Fatalf("got %+v, ok=%v, want BAD_WINDOW", result, ok)
+		}
+	})
+
+	t.Run("unknown challenge", func(t *testing.T) {
+		_, ok := g.ScoreResponse(TargetResponse{ChallengeID: "NOPE"})
+		if ok {
+			t.Fatal("got ok=true for an unknown challenge ID")
+		}
+	})
+}
@@ -0,0 +1,508 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// This file is a small, dependency-free msgpack codec covering the
+// shapes miniboi actually puts on the wire: structs of strings, bools,
+// numbers and nested structs/slices, keyed the same way encoding/json
+// would key them (struct field `json` tags). It is not a general
+// msgpack implementation - no extension types, no binary blobs - but it
+// round-trips everything in this package without pulling in a
+// third-party dependency for what is otherwise a handful of structs.
+
+// MsgpackEncoder writes values to w in msgpack wire format.
+type MsgpackEncoder struct {
+	w io.Writer
+}
+
+// NewMsgpackEncoder returns an Encoder that writes msgpack to w.
+func NewMsgpackEncoder(w io.Writer) *MsgpackEncoder {
+	return &MsgpackEncoder{w: w}
+}
+
+// Encode marshals v and writes it to the underlying writer.
+func (e *MsgpackEncoder) Encode(v interface{}) error {
+	data, err := Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+// MsgpackDecoder reads values out of r in msgpack wire format.
+type MsgpackDecoder struct {
+	r io.Reader
+}
+
+// NewMsgpackDecoder returns a Decoder that reads msgpack from r.
+func NewMsgpackDecoder(r io.Reader) *MsgpackDecoder {
+	return &MsgpackDecoder{r: r}
+}
+
+// Decode reads the next msgpack value from the underlying reader into v.
+// Like a single json.Decoder.Decode call, it consumes exactly one
+// encoded value; callers that send one value per message (the only
+// pattern this package uses) can call it directly on the message body.
+func (d *MsgpackDecoder) Decode(v interface{}) error {
+	data, err := io.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(data, v)
+}
+
+// Marshal returns the msgpack encoding of v.
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeValue(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal parses msgpack-encoded data into v, which must be a
+// non-nil pointer.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("wire: msgpack Unmarshal target must be a non-nil pointer")
+	}
+	dec := &msgpackReader{buf: data}
+	raw, err := dec.decodeAny()
+	if err != nil {
+		return err
+	}
+	return populate(rv.Elem(), raw)
+}
+
+// --- encode ---
+
+func encodeValue(buf *bytes.Buffer, rv reflect.Value) error {
+	if !rv.IsValid() {
+		buf.WriteByte(0xc0) // nil
+		return nil
+	}
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case reflect.String:
+		encodeString(buf, rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		encodeInt64(buf, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		encodeInt64(buf, int64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		encodeFloat64(buf, rv.Float())
+	case reflect.Slice, reflect.Array:
+		n := rv.Len()
+		encodeArrayHeader(buf, n)
+		for i := 0; i < n; i++ {
+			if err := encodeValue(buf, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		encodeMapHeader(buf, len(keys))
+		for _, k := range keys {
+			encodeString(buf, fmt.Sprintf("%v", k.Interface()))
+			if err := encodeValue(buf, rv.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		fields := structFields(rv.Type())
+		encodeMapHeader(buf, len(fields))
+		for _, f := range fields {
+			encodeString(buf, f.name)
+			if err := encodeValue(buf, rv.FieldByIndex(f.index)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("wire: msgpack cannot encode %s", rv.Kind())
+	}
+	return nil
+}
+
+func encodeString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n < 1<<16:
+		buf.WriteByte(0xda)
+		writeUint16(buf, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		writeUint32(buf, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+func encodeInt64(buf *bytes.Buffer, i int64) {
+	buf.WriteByte(0xd3)
+	writeUint64(buf, uint64(i))
+}
+
+func encodeFloat64(buf *bytes.Buffer, f float64) {
+	buf.WriteByte(0xcb)
+	writeUint64(buf, math.Float64bits(f))
+}
+
+func encodeArrayHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x90 | byte(n))
+	case n < 1<<16:
+		buf.WriteByte(0xdc)
+		writeUint16(buf, uint16(n))
+	default:
+		buf.WriteByte(0xdd)
+		writeUint32(buf, uint32(n))
+	}
+}
+
+func encodeMapHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x80 | byte(n))
+	case n < 1<<16:
+		buf.WriteByte(0xde)
+		writeUint16(buf, uint16(n))
+	default:
+		buf.WriteByte(0xdf)
+		writeUint32(buf, uint32(n))
+	}
+}
+
+// --- decode ---
+
+type msgpackReader struct {
+	buf []byte
+	pos int
+}
+
+func (d *msgpackReader) readByte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *msgpackReader) readN(n int) ([]byte, error) {
+	if d.pos+n > len(d.buf) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// decodeAny decodes the next value into a generic interface{} tree:
+// map[string]interface{}, []interface{}, string, int64, float64, bool
+// or nil - mirroring what encoding/json does for untyped Unmarshal.
+func (d *msgpackReader) decodeAny() (interface{}, error) {
+	b, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b == 0xc0:
+		return nil, nil
+	case b == 0xc2:
+		return false, nil
+	case b == 0xc3:
+		return true, nil
+	case b == 0xcb:
+		raw, err := d.readN(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(readUint64(raw)), nil
+	case b == 0xd3:
+		raw, err := d.readN(8)
+		if err != nil {
+			return nil, err
+		}
+		return int64(readUint64(raw)), nil
+	case b>>5 == 0x05: // fixstr 0xa0-0xbf
+		return d.readString(int(b & 0x1f))
+	case b == 0xda:
+		raw, err := d.readN(2)
+		if err != nil {
+			return nil, err
+		}
+		return d.readString(int(readUint16(raw)))
+	case b == 0xdb:
+		raw, err := d.readN(4)
+		if err != nil {
+			return nil, err
+		}
+		return d.readString(int(readUint32(raw)))
+	case b>>4 == 0x09: // fixarray 0x90-0x9f
+		return d.readArray(int(b & 0x0f))
+	case b == 0xdc:
+		raw, err := d.readN(2)
+		if err != nil {
+			return nil, err
+		}
+		return d.readArray(int(readUint16(raw)))
+	case b == 0xdd:
+		raw, err := d.readN(4)
+		if err != nil {
+			return nil, err
+		}
+		return d.readArray(int(readUint32(raw)))
+	case b>>4 == 0x08: // fixmap 0x80-0x8f
+		return d.readMap(int(b & 0x0f))
+	case b == 0xde:
+		raw, err := d.readN(2)
+		if err != nil {
+			return nil, err
+		}
+		return d.readMap(int(readUint16(raw)))
+	case b == 0xdf:
+		raw, err := d.readN(4)
+		if err != nil {
+			return nil, err
+		}
+		return d.readMap(int(readUint32(raw)))
+	default:
+		return nil, fmt.Errorf("wire: msgpack unsupported type byte 0x%x", b)
+	}
+}
+
+func (d *msgpackReader) readString(n int) (string, error) {
+	raw, err := d.readN(n)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func (d *msgpackReader) readArray(n int) ([]interface{}, error) {
+	out := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		v, err := d.decodeAny()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func (d *msgpackReader) readMap(n int) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := d.decodeAny()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decodeAny()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("wire: msgpack map key is not a string: %v", k)
+		}
+		out[key] = v
+	}
+	return out, nil
+}
+
+// populate fills dst (an addressable reflect.Value) from a generic
+// interface{} tree produced by decodeAny, the same way json.Unmarshal
+// populates a struct from a map[string]interface{}.
+func populate(dst reflect.Value, raw interface{}) error {
+	if raw == nil {
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return populate(dst.Elem(), raw)
+	case reflect.Struct:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("wire: msgpack expected map for struct %s, got %T", dst.Type(), raw)
+		}
+		for _, f := range structFields(dst.Type()) {
+			if v, ok := m[f.name]; ok {
+				if err := populate(dst.FieldByIndex(f.index), v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case reflect.Slice:
+		a, ok := raw.([]interface{})
+		if !ok {
+			return fmt.Errorf("wire: msgpack expected array for %s, got %T", dst.Type(), raw)
+		}
+		out := reflect.MakeSlice(dst.Type(), len(a), len(a))
+		for i, v := range a {
+			if err := populate(out.Index(i), v); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+		return nil
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("wire: msgpack expected string for %s, got %T", dst.Type(), raw)
+		}
+		dst.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("wire: msgpack expected bool for %s, got %T", dst.Type(), raw)
+		}
+		dst.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := asInt64(raw)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := asInt64(raw)
+		if err != nil {
+			return err
+		}
+		dst.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := asFloat64(raw)
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+		return nil
+	default:
+		return fmt.Errorf("wire: msgpack cannot populate %s", dst.Kind())
+	}
+}
+
+func asInt64(raw interface{}) (int64, error) {
+	switch n := raw.(type) {
+	case int64:
+		return n, nil
+	case float64:
+		return int64(n), nil
+	default:
+		return 0, fmt.Errorf("wire: msgpack expected number, got %T", raw)
+	}
+}
+
+func asFloat64(raw interface{}) (float64, error) {
+	switch n := raw.(type) {
+	case float64:
+		return n, nil
+	case int64:
+		return float64(n), nil
+	default:
+		return 0, fmt.Errorf("wire: msgpack expected number, got %T", raw)
+	}
+}
+
+// --- struct field naming, shared by encode and populate ---
+
+type field struct {
+	name  string
+	index []int
+}
+
+// structFields returns the exported fields of t in declaration order,
+// named the same way their `json` tag would name them, skipping any
+// field tagged `json:"-"`. Anonymous (embedded) fields are not expanded
+// specially - none of miniboi's wire structs use embedding.
+func structFields(t reflect.Type) []field {
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" { // unexported
+			continue
+		}
+		name := sf.Name
+		if tag := sf.Tag.Get("json"); tag != "" {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+		}
+		fields = append(fields, field{name: name, index: sf.Index})
+	}
+	return fields
+}
+
+func writeUint16(buf *bytes.Buffer, v uint16) {
+	buf.WriteByte(byte(v >> 8))
+	buf.WriteByte(byte(v))
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	buf.WriteByte(byte(v >> 24))
+	buf.WriteByte(byte(v >> 16))
+	buf.WriteByte(byte(v >> 8))
+	buf.WriteByte(byte(v))
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	for shift := 56; shift >= 0; shift -= 8 {
+		buf.WriteByte(byte(v >> uint(shift)))
+	}
+}
+
+func readUint16(b []byte) uint16 {
+	return uint16(b[0])<<8 | uint16(b[1])
+}
+
+func readUint32(b []byte) uint32 {
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+func readUint64(b []byte) uint64 {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
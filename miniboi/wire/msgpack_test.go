@@ -0,0 +1,97 @@
+package wire
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestRequest(query, accept, contentType string) *http.Request {
+	url := "/mission/coordinates"
+	if query != "" {
+		url += "?encoding=" + query
+	}
+	r := httptest.NewRequest(http.MethodGet, url, nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	in := sampleFrame()
+
+	data, err := Marshal(&in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out benchFrame
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if out.ChallengeID != in.ChallengeID || out.Turn != in.Turn || out.Timestamp != in.Timestamp {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", out, in)
+	}
+	if len(out.Targets) != len(in.Targets) {
+		t.Fatalf("target count mismatch: got %d, want %d", len(out.Targets), len(in.Targets))
+	}
+	if out.Targets[0].X != in.Targets[0].X || out.Targets[0].VY != in.Targets[0].VY {
+		t.Fatalf("target field mismatch: got %+v, want %+v", out.Targets[0], in.Targets[0])
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	// Exercised indirectly by cmd/miniboid; covered here at the unit
+	// level since it's pure string/header parsing.
+	cases := []struct {
+		query, accept, contentType string
+		want                       Format
+	}{
+		{query: "msgpack", want: Msgpack},
+		{query: "gob", want: Gob},
+		{accept: "application/x-msgpack", want: Msgpack},
+		{want: JSON},
+		// A msgpack request body with an Accept asking for JSON back:
+		// Negotiate governs the response, so Accept must win here even
+		// though Content-Type disagrees.
+		{accept: "application/json", contentType: "application/x-msgpack", want: JSON},
+	}
+
+	for _, c := range cases {
+		r := newTestRequest(c.query, c.accept, c.contentType)
+		got, _ := Negotiate(r)
+		if got != c.want {
+			t.Errorf("Negotiate(query=%q, accept=%q, content-type=%q) = %q, want %q",
+				c.query, c.accept, c.contentType, got, c.want)
+		}
+	}
+}
+
+func TestNegotiateDecode(t *testing.T) {
+	cases := []struct {
+		query, accept, contentType string
+		want                       Format
+	}{
+		{query: "msgpack", want: Msgpack},
+		{contentType: "application/x-gob", want: Gob},
+		{want: JSON},
+		// The inverse of the TestNegotiate conflict case above:
+		// NegotiateDecode governs the request body's own encoding, so
+		// Content-Type must win even though Accept disagrees.
+		{accept: "application/json", contentType: "application/x-msgpack", want: Msgpack},
+	}
+
+	for _, c := range cases {
+		r := newTestRequest(c.query, c.accept, c.contentType)
+		got, _ := NegotiateDecode(r)
+		if got != c.want {
+			t.Errorf("NegotiateDecode(query=%q, accept=%q, content-type=%q) = %q, want %q",
+				c.query, c.accept, c.contentType, got, c.want)
+		}
+	}
+}
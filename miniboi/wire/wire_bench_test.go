@@ -0,0 +1,71 @@
+package wire
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// benchCoordinate and benchFrame mirror the shape of a Boardstate frame
+// (player + a handful of drifting targets) without importing the
+// miniboi package, which already imports wire.
+type benchCoordinate struct {
+	ID string  `json:"id"`
+	X  float64 `json:"x"`
+	Y  float64 `json:"y"`
+	Z  float64 `json:"z"`
+	VX float64 `json:"vx,omitempty"`
+	VY float64 `json:"vy,omitempty"`
+	VZ float64 `json:"vz,omitempty"`
+}
+
+type benchFrame struct {
+	Type        string            `json:"type"`
+	Turn        int64             `json:"turn"`
+	ChallengeID string            `json:"challenge_id"`
+	PlayerPos   benchCoordinate   `json:"player_position"`
+	Targets     []benchCoordinate `json:"targets"`
+	Timestamp   int64             `json:"timestamp"`
+}
+
+func sampleFrame() benchFrame {
+	targets := make([]benchCoordinate, 8)
+	for i := range targets {
+		targets[i] = benchCoordinate{
+			ID: "T1", X: 12.5, Y: -30.2, Z: 4.1, VX: 1.2, VY: -0.4, VZ: 0.1,
+		}
+	}
+	return benchFrame{
+		Type:        "boardstate",
+		Turn:        42,
+		ChallengeID: "TARG-1-0042",
+		PlayerPos:   benchCoordinate{ID: "PLAYER"},
+		Targets:     targets,
+		Timestamp:   time.Now().Unix(),
+	}
+}
+
+// roundTrip encodes frame then decodes it back into a fresh benchFrame,
+// the same encode-then-decode cycle a 20Hz boardstate push goes
+// through on the wire.
+func roundTrip(b *testing.B, format Format) {
+	frame := sampleFrame()
+	var buf bytes.Buffer
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := NewEncoder(format, &buf).Encode(&frame); err != nil {
+			b.Fatal(err)
+		}
+		var out benchFrame
+		if err := NewDecoder(format, bytes.NewReader(buf.Bytes())).Decode(&out); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.SetBytes(int64(buf.Len()))
+}
+
+func BenchmarkRoundTripJSON(b *testing.B)    { roundTrip(b, JSON) }
+func BenchmarkRoundTripGob(b *testing.B)     { roundTrip(b, Gob) }
+func BenchmarkRoundTripMsgpack(b *testing.B) { roundTrip(b, Msgpack) }
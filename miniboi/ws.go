@@ -0,0 +1,211 @@
+package miniboi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FrameType discriminates the different message shapes multiplexed over
+// a mission socket so a single connection can carry boardstate pushes,
+// acks, and errors.
+type FrameType string
+
+const (
+	FrameBoardstate FrameType = "boardstate"
+	FrameAck        FrameType = "ack"
+	FrameError      FrameType = "error"
+)
+
+// Boardstate is pushed to every client connected to a Game's mission
+// socket on each tick of its broadcast loop.
+type Boardstate struct {
+	Type        FrameType    `json:"type"`
+	Turn        int64        `json:"turn"`
+	ChallengeID string       `json:"challenge_id"`
+	PlayerPos   Coordinate   `json:"player_position"`
+	Targets     []Coordinate `json:"targets"`
+	Timestamp   int64        `json:"timestamp"`
+}
+
+// Instruction is the inbound message a client sends to answer whichever
+// boardstate frame it is reacting to.
+type Instruction struct {
+	Type            FrameType `json:"type"`
+	ChallengeID     string    `json:"challenge_id"`
+	ClosestTargetID string    `json:"closest_target_id"`
+	// InterceptTime is how many seconds after the challenge was issued
+	// the client predicts their chosen target is closest - see
+	// TargetResponse.InterceptTime, which this mirrors for the live
+	// socket path.
+	InterceptTime float64 `json:"intercept_time"`
+	PlayerID      string  `json:"player_id,omitempty"`
+}
+
+// AckFrame reports a hit or miss for a submitted Instruction.
+type AckFrame struct {
+	Type        FrameType `json:"type"`
+	ChallengeID string    `json:"challenge_id"`
+	Status      string    `json:"status"`
+	Message     string    `json:"message"`
+}
+
+// ErrorFrame reports a problem with an inbound message or a stale
+// challenge reference.
+type ErrorFrame struct {
+	Type        FrameType `json:"type"`
+	ChallengeID string    `json:"challenge_id,omitempty"`
+	Message     string    `json:"message"`
+}
+
+// MissionSink is anything that can receive marshaled mission frames -
+// satisfied by a websocket connection's send channel. Keeping the hub
+// decoupled from gorilla/websocket lets cmd/miniboid own the transport.
+type MissionSink interface {
+	// Send serializes v in whatever wire format this sink negotiated and
+	// delivers it to the client. Errors are the sink's own business to
+	// log or drop; the hub doesn't want one slow/broken client to block
+	// the broadcast loop for everyone else.
+	Send(v interface{})
+	// Close tears down the sink's underlying connection. The hub calls
+	// this on every still-registered sink when it stops, so a game
+	// shutting down doesn't leave orphaned clients blocked forever on a
+	// read that will now never get another frame.
+	Close()
+}
+
+// MissionHub fans a Game's boardstate out to every subscribed sink, and
+// scores Instructions against that Game's pending challenges. One hub
+// per Game, the same way ws_mission.go used to keep one global client
+// map for the single game the server ran.
+type MissionHub struct {
+	game *Game
+
+	mu       sync.RWMutex
+	sinks    map[string]MissionSink
+	turn     int64
+	tickRate time.Duration
+
+	stopCh chan struct{}
+}
+
+// NewMissionHub builds a hub that broadcasts g's boardstate at
+// tickRate and starts its broadcast loop.
+func NewMissionHub(g *Game, tickRate time.Duration) *MissionHub {
+	h := &MissionHub{
+		game:     g,
+		sinks:    make(map[string]MissionSink),
+		tickRate: tickRate,
+		stopCh:   make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+// Register adds a sink to the broadcast set under id.
+func (h *MissionHub) Register(id string, sink MissionSink) {
+	h.mu.Lock()
+	h.sinks[id] = sink
+	h.mu.Unlock()
+}
+
+// Unregister removes a sink from the broadcast set.
+func (h *MissionHub) Unregister(id string) {
+	h.mu.Lock()
+	delete(h.sinks, id)
+	h.mu.Unlock()
+}
+
+// Stop halts the hub's broadcast loop and closes every still-registered
+// sink, so their owning connections' read loops unblock instead of
+// hanging forever once this game can no longer push them any frames.
+func (h *MissionHub) Stop() {
+	close(h.stopCh)
+
+	h.mu.Lock()
+	sinks := make([]MissionSink, 0, len(h.sinks))
+	for _, sink := range h.sinks {
+		sinks = append(sinks, sink)
+	}
+	h.sinks = make(map[string]MissionSink)
+	h.mu.Unlock()
+
+	for _, sink := range sinks {
+		sink.Close()
+	}
+}
+
+func (h *MissionHub) run() {
+	ticker := time.NewTicker(h.tickRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.stopCh:
+			return
+		case <-ticker.C:
+			h.broadcast()
+		}
+	}
+}
+
+func (h *MissionHub) broadcast() {
+	// The encoding stamped on the challenge itself is moot here since
+	// every sink re-encodes the frame in its own negotiated format below.
+	challenge, _ := h.game.NewChallenge("")
+
+	h.mu.Lock()
+	h.turn++
+	turn := h.turn
+	h.mu.Unlock()
+
+	frame := Boardstate{
+		Type:        FrameBoardstate,
+		Turn:        turn,
+		ChallengeID: challenge.ChallengeID,
+		PlayerPos:   challenge.PlayerPos,
+		Targets:     challenge.Targets,
+		Timestamp:   time.Now().Unix(),
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, sink := range h.sinks {
+		sink.Send(frame)
+	}
+}
+
+// HandleInstruction scores an Instruction against whichever challenge it
+// references in the hub's Game, and returns the frame to send back.
+func (h *MissionHub) HandleInstruction(instr Instruction) AckFrame {
+	response := TargetResponse{
+		ChallengeID:   instr.ChallengeID,
+		ClosestID:     instr.ClosestTargetID,
+		InterceptTime: instr.InterceptTime,
+		PlayerID:      instr.PlayerID,
+	}
+
+	result, exists := h.game.ScoreResponse(response)
+	if !exists {
+		return AckFrame{} // caller checks exists separately via ErrorFrame path
+	}
+
+	ack := AckFrame{Type: FrameAck, ChallengeID: instr.ChallengeID, Status: result.Status}
+	switch result.Status {
+	case "HIT":
+		ack.Message = fmt.Sprintf("Target acquired (intercept error %.3fs)", result.InterceptError)
+	case "BAD_WINDOW":
+		ack.Message = "INTERCEPT OUTSIDE TICK WINDOW! Re-acquire and try again."
+	case "OCCLUDED":
+		ack.Message = fmt.Sprintf("%s was closer, but an obstacle blocked your line of sight", result.CorrectTarget)
+	default:
+		ack.Message = fmt.Sprintf("Wrong target, expected %s", result.CorrectTarget)
+	}
+	return ack
+}
+
+// ChallengeExists reports whether instr references a still-pending
+// challenge, for callers that need to send an ErrorFrame otherwise.
+func (h *MissionHub) ChallengeExists(id string) bool {
+	_, ok := h.game.Challenge(id)
+	return ok
+}
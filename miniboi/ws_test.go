@@ -0,0 +1,61 @@
+package miniboi
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSink is a MissionSink test double that records frames instead of
+// writing them to a real connection.
+type fakeSink struct {
+	mu     sync.Mutex
+	frames []interface{}
+	closed bool
+}
+
+func (s *fakeSink) Send(v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frames = append(s.frames, v)
+}
+
+func (s *fakeSink) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *fakeSink) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func TestMissionHubStopClosesRegisteredSinks(t *testing.T) {
+	g := newGame("TEST-HUB-STOP", testConfig())
+	defer g.stop()
+
+	hub := NewMissionHub(g, 5*time.Millisecond)
+	sink := &fakeSink{}
+	hub.Register("client-1", sink)
+
+	hub.Stop()
+
+	if !sink.isClosed() {
+		t.Fatal("Stop() did not close a still-registered sink")
+	}
+}
+
+func TestMissionHubHandleInstructionUnknownChallenge(t *testing.T) {
+	g := newGame("TEST-HUB-UNKNOWN", testConfig())
+	defer g.stop()
+
+	hub := NewMissionHub(g, time.Second)
+	defer hub.Stop()
+
+	ack := hub.HandleInstruction(Instruction{ChallengeID: "NOPE"})
+	if ack != (AckFrame{}) {
+		t.Fatalf("HandleInstruction for an unknown challenge = %+v, want zero value", ack)
+	}
+}